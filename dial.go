@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// dialTCP opens addr, routing through options.Proxy when set. It is the
+// single place net connections get made from, so proxying, timeouts, and
+// (eventually) retries stay consistent across the connect scan, probes, and
+// banner grabbing.
+func dialTCP(ctx context.Context, options ScanOptions, addr string) (net.Conn, error) {
+	timeout := time.Duration(options.Timeout) * time.Millisecond
+
+	if options.Proxy == "" {
+		dialer := net.Dialer{Timeout: timeout}
+		return dialer.DialContext(ctx, "tcp", addr)
+	}
+
+	proxyURL, err := url.Parse(options.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -proxy value %q: %w", options.Proxy, err)
+	}
+
+	switch proxyURL.Scheme {
+	case "socks5", "socks5h":
+		return dialSOCKS5(ctx, proxyURL, addr, timeout)
+	case "http", "https":
+		return dialHTTPConnect(ctx, proxyURL, addr, timeout)
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q (want socks5:// or http://)", proxyURL.Scheme)
+	}
+}
+
+// dialSOCKS5 dials addr through a SOCKS5 proxy, optionally authenticating
+// with the username/password embedded in proxyURL.
+func dialSOCKS5(ctx context.Context, proxyURL *url.URL, addr string, timeout time.Duration) (net.Conn, error) {
+	var auth *proxy.Auth
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		auth = &proxy.Auth{User: proxyURL.User.Username(), Password: password}
+	}
+
+	dialer, err := proxy.SOCKS5("tcp", proxyURL.Host, auth, &net.Dialer{Timeout: timeout})
+	if err != nil {
+		return nil, fmt.Errorf("socks5 dialer setup failed: %w", err)
+	}
+
+	if ctxDialer, ok := dialer.(proxy.ContextDialer); ok {
+		return ctxDialer.DialContext(ctx, "tcp", addr)
+	}
+	return dialer.Dial("tcp", addr)
+}
+
+// dialHTTPConnect dials addr through an HTTP proxy using the CONNECT
+// method, the standard way to tunnel arbitrary TCP through an HTTP proxy.
+func dialHTTPConnect(ctx context.Context, proxyURL *url.URL, addr string, timeout time.Duration) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", proxyURL.Host)
+	if err != nil {
+		return nil, fmt.Errorf("http proxy connect failed: %w", err)
+	}
+
+	req := &http.Request{
+		Method: "CONNECT",
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		req.SetBasicAuth(proxyURL.User.Username(), password)
+	}
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("http proxy CONNECT request failed: %w", err)
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("http proxy CONNECT response failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("http proxy CONNECT rejected: %s", resp.Status)
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// runCollector is the single goroutine allowed to write scan output. Every
+// scan mode pushes Results onto one channel and lets this serialize the
+// writes, which is how multi-worker output stops interleaving garbled
+// lines across stdout and (optionally) an output file.
+func runCollector(results <-chan Result, options ScanOptions) error {
+	var w io.Writer = os.Stdout
+
+	if options.Output != "" {
+		f, err := os.Create(options.Output)
+		if err != nil {
+			return fmt.Errorf("creating output file %s: %w", options.Output, err)
+		}
+		defer f.Close()
+		w = io.MultiWriter(os.Stdout, f)
+	}
+
+	switch options.Format {
+	case "jsonl":
+		writeJSONL(w, results)
+	case "json":
+		writeJSON(w, results)
+	case "csv":
+		writeCSV(w, results)
+	default:
+		writeText(w, results, options.Verbose)
+	}
+
+	return nil
+}
+
+func writeJSONL(w io.Writer, results <-chan Result) {
+	enc := json.NewEncoder(w)
+	for r := range results {
+		enc.Encode(r)
+	}
+}
+
+func writeJSON(w io.Writer, results <-chan Result) {
+	var all []Result
+	for r := range results {
+		all = append(all, r)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(all)
+}
+
+func writeCSV(w io.Writer, results <-chan Result) {
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	cw.Write([]string{"host", "port", "proto", "state", "service", "banner", "latency_ms", "error"})
+	for r := range results {
+		cw.Write([]string{
+			r.Host,
+			strconv.Itoa(r.Port),
+			r.Proto,
+			r.State,
+			r.Service,
+			r.Banner,
+			strconv.FormatFloat(r.Latency.Seconds()*1000, 'f', 2, 64),
+			r.Error,
+		})
+	}
+}
+
+func writeText(w io.Writer, results <-chan Result, verbose bool) {
+	for r := range results {
+		if r.State != "open" {
+			if verbose {
+				if r.Error != "" {
+					fmt.Fprintf(w, "%s:%d/%s %s (%s)\n", r.Host, r.Port, r.Proto, r.State, r.Error)
+				} else {
+					fmt.Fprintf(w, "%s:%d/%s %s\n", r.Host, r.Port, r.Proto, r.State)
+				}
+			}
+			continue
+		}
+
+		if r.Service != "" {
+			fmt.Fprintf(w, "%s:%d/%s open - %s\n", r.Host, r.Port, r.Proto, r.Service)
+		} else {
+			fmt.Fprintf(w, "%s:%d/%s open\n", r.Host, r.Port, r.Proto)
+		}
+		if r.Banner != "" {
+			fmt.Fprintf(w, "  └─ Banner: %s\n", r.Banner)
+		}
+		for name, probeResult := range r.ProbeData {
+			fmt.Fprintf(w, "  └─ [%s] %s\n", name, probeResult.Service)
+			if probeResult.Version != "" {
+				fmt.Fprintf(w, "      version: %s\n", probeResult.Version)
+			}
+			for _, vuln := range probeResult.Vulnerable {
+				fmt.Fprintf(w, "      vulnerable: %s\n", vuln)
+			}
+			for _, cred := range probeResult.Credentials {
+				fmt.Fprintf(w, "      credential: %s\n", cred)
+			}
+			for key, value := range probeResult.Extra {
+				fmt.Fprintf(w, "      %s: %s\n", key, value)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteCSV(t *testing.T) {
+	results := make(chan Result, 2)
+	results <- Result{Host: "10.0.0.1", Port: 22, Proto: "tcp", State: "open", Service: "ssh", Latency: 5 * time.Millisecond}
+	results <- Result{Host: "10.0.0.1", Port: 23, Proto: "tcp", State: "closed", Error: "connection refused"}
+	close(results)
+
+	var buf bytes.Buffer
+	writeCSV(&buf, results)
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("parsing writeCSV output: %v", err)
+	}
+	if len(rows) != 3 { // header + 2 results
+		t.Fatalf("got %d rows, want 3: %v", len(rows), rows)
+	}
+	if rows[0][0] != "host" || rows[0][3] != "state" {
+		t.Errorf("unexpected header row: %v", rows[0])
+	}
+	if rows[1][0] != "10.0.0.1" || rows[1][1] != "22" || rows[1][3] != "open" || rows[1][6] != "5.00" {
+		t.Errorf("unexpected open row: %v", rows[1])
+	}
+	if rows[2][3] != "closed" || rows[2][7] != "connection refused" {
+		t.Errorf("unexpected closed row: %v", rows[2])
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	results := make(chan Result, 2)
+	results <- Result{Host: "10.0.0.1", Port: 80, Proto: "tcp", State: "open", Service: "http"}
+	results <- Result{Host: "10.0.0.1", Port: 81, Proto: "tcp", State: "filtered"}
+	close(results)
+
+	var buf bytes.Buffer
+	writeJSON(&buf, results)
+
+	var got []Result
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshalling writeJSON output: %v (raw: %s)", err, buf.String())
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d results, want 2", len(got))
+	}
+	if got[0].Port != 80 || got[0].Service != "http" {
+		t.Errorf("unexpected first result: %+v", got[0])
+	}
+	if got[1].State != "filtered" {
+		t.Errorf("unexpected second result: %+v", got[1])
+	}
+}
+
+func TestWriteJSONL(t *testing.T) {
+	results := make(chan Result, 2)
+	results <- Result{Host: "10.0.0.1", Port: 80, State: "open"}
+	results <- Result{Host: "10.0.0.1", Port: 81, State: "closed"}
+	close(results)
+
+	var buf bytes.Buffer
+	writeJSONL(&buf, results)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var r Result
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("line %d not valid JSON: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package probes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register(&ftpProbe{})
+}
+
+// ftpProbe reads the FTP welcome banner and tries anonymous plus any
+// supplied credentials.
+type ftpProbe struct{}
+
+func (p *ftpProbe) Name() string { return "ftp" }
+
+func (p *ftpProbe) Match(port int, banner []byte) bool {
+	if port == 21 {
+		return true
+	}
+	return strings.HasPrefix(string(banner), "220")
+}
+
+func (p *ftpProbe) Run(ctx context.Context, conn net.Conn, opts Options) (ProbeResult, error) {
+	if opts.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(opts.Timeout))
+	}
+
+	tp := textproto.NewConn(conn)
+	_, banner, err := tp.ReadResponse(220)
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("ftp: no welcome banner: %w", err)
+	}
+
+	result := ProbeResult{
+		Service: "ftp",
+		Banner:  banner,
+	}
+
+	creds := append([]Credential{{Username: "anonymous", Password: "anonymous@"}}, opts.Credentials...)
+	for _, cred := range creds {
+		if err := ftpLogin(tp, cred); err == nil {
+			result.Credentials = append(result.Credentials, fmt.Sprintf("%s:%s", cred.Username, cred.Password))
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func ftpLogin(tp *textproto.Conn, cred Credential) error {
+	if err := tp.PrintfLine("USER %s", cred.Username); err != nil {
+		return err
+	}
+	code, _, err := tp.ReadResponse(0)
+	if err != nil {
+		return err
+	}
+	if code == 230 {
+		return nil // server let us in without a password
+	}
+	if code != 331 {
+		return fmt.Errorf("ftp: unexpected USER response %d", code)
+	}
+
+	if err := tp.PrintfLine("PASS %s", cred.Password); err != nil {
+		return err
+	}
+	code, _, err = tp.ReadResponse(0)
+	if err != nil {
+		return err
+	}
+	if code != 230 {
+		return fmt.Errorf("ftp: login rejected (%d)", code)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package probes
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register(&httpProbe{})
+}
+
+// httpProbe sends a HEAD request and records the server header and status
+// line. It matches plaintext HTTP only; TLS-wrapped HTTP is handled by the
+// tls probe once the handshake has completed.
+type httpProbe struct{}
+
+func (p *httpProbe) Name() string { return "http" }
+
+func (p *httpProbe) Match(port int, banner []byte) bool {
+	switch port {
+	case 80, 8000, 8080, 8888:
+		return true
+	}
+	return strings.HasPrefix(string(banner), "HTTP/")
+}
+
+func (p *httpProbe) Run(ctx context.Context, conn net.Conn, opts Options) (ProbeResult, error) {
+	if opts.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(opts.Timeout))
+	}
+
+	req := fmt.Sprintf("HEAD / HTTP/1.1\r\nHost: %s\r\nConnection: close\r\n\r\n", hostOf(conn))
+	if _, err := conn.Write([]byte(req)); err != nil {
+		return ProbeResult{}, fmt.Errorf("http: request failed: %w", err)
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("http: no response: %w", err)
+	}
+	defer resp.Body.Close()
+
+	result := ProbeResult{
+		Service: "http",
+		Banner:  resp.Status,
+		Extra:   map[string]string{"status": resp.Status},
+	}
+	if server := resp.Header.Get("Server"); server != "" {
+		result.Version = server
+		result.Extra["server"] = server
+	}
+
+	return result, nil
+}
+
+func hostOf(conn net.Conn) string {
+	host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return conn.RemoteAddr().String()
+	}
+	return host
+}
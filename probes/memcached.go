@@ -0,0 +1,57 @@
+package probes
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register(&memcachedProbe{})
+}
+
+// memcachedProbe issues a "stats" command, which memcached answers without
+// any authentication; an exposed instance is itself the finding, since it
+// commonly allows unauthenticated reads/writes and UDP reflection abuse.
+type memcachedProbe struct{}
+
+func (p *memcachedProbe) Name() string { return "memcached" }
+
+func (p *memcachedProbe) Match(port int, banner []byte) bool {
+	return port == 11211
+}
+
+func (p *memcachedProbe) Run(ctx context.Context, conn net.Conn, opts Options) (ProbeResult, error) {
+	if opts.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(opts.Timeout))
+	}
+
+	if _, err := conn.Write([]byte("stats\r\n")); err != nil {
+		return ProbeResult{}, fmt.Errorf("memcached: stats request failed: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	result := ProbeResult{
+		Service: "memcached",
+		Extra:   map[string]string{"auth": "none (stats answered unauthenticated)"},
+	}
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			break
+		}
+		line = strings.TrimSpace(line)
+		if line == "END" || line == "" {
+			break
+		}
+		if v, ok := strings.CutPrefix(line, "STAT version "); ok {
+			result.Version = v
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,82 @@
+package probes
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register(&mongodbProbe{})
+}
+
+// mongodbProbe sends an isMaster OP_QUERY and checks whether the server
+// answers without requiring authentication.
+type mongodbProbe struct{}
+
+func (p *mongodbProbe) Name() string { return "mongodb" }
+
+func (p *mongodbProbe) Match(port int, banner []byte) bool {
+	return port == 27017
+}
+
+func (p *mongodbProbe) Run(ctx context.Context, conn net.Conn, opts Options) (ProbeResult, error) {
+	if opts.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(opts.Timeout))
+	}
+
+	query := bsonIsMasterQuery()
+	if _, err := conn.Write(query); err != nil {
+		return ProbeResult{}, fmt.Errorf("mongodb: isMaster request failed: %w", err)
+	}
+
+	buf := make([]byte, 1024)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("mongodb: no response: %w", err)
+	}
+
+	result := ProbeResult{Service: "mongodb"}
+	if n >= 16 {
+		result.Extra = addExtra(result.Extra, "auth", "none (isMaster answered without credentials)")
+	}
+	if idx := indexOf(buf[:n], []byte("version")); idx >= 0 {
+		result.Extra = addExtra(result.Extra, "raw_version_field", "present")
+	}
+
+	return result, nil
+}
+
+func indexOf(haystack, needle []byte) int {
+	return strings.Index(string(haystack), string(needle))
+}
+
+// bsonIsMasterQuery builds the legacy OP_QUERY wire-protocol message for
+// {isMaster: 1} against admin.$cmd, which every MongoDB version since 2.6
+// answers even when access control is enabled.
+func bsonIsMasterQuery() []byte {
+	doc := []byte{
+		0x0e, 0x00, 0x00, 0x00, // document length
+		0x10, 'i', 's', 'M', 'a', 's', 't', 'e', 'r', 0x00, 0x01, 0x00, 0x00, 0x00,
+		0x00,
+	}
+	collection := append([]byte("admin.$cmd"), 0x00)
+
+	body := make([]byte, 0, 64)
+	body = append(body, 0, 0, 0, 0) // flags
+	body = append(body, collection...)
+	body = append(body, 0, 0, 0, 0) // numberToSkip
+	body = append(body, 1, 0, 0, 0) // numberToReturn
+	body = append(body, doc...)
+
+	header := make([]byte, 16)
+	binary.LittleEndian.PutUint32(header[0:4], uint32(16+len(body)))
+	binary.LittleEndian.PutUint32(header[4:8], 1)      // requestID
+	binary.LittleEndian.PutUint32(header[8:12], 0)     // responseTo
+	binary.LittleEndian.PutUint32(header[12:16], 2004) // opCode: OP_QUERY
+
+	return append(header, body...)
+}
@@ -0,0 +1,57 @@
+package probes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+func init() {
+	Register(&mssqlProbe{})
+}
+
+// mssqlProbe sends a TDS pre-login packet and reports whether the server
+// responded, which is enough to fingerprint the service even without
+// parsing the (binary, version-specific) pre-login payload in full.
+type mssqlProbe struct{}
+
+func (p *mssqlProbe) Name() string { return "mssql" }
+
+func (p *mssqlProbe) Match(port int, banner []byte) bool {
+	return port == 1433
+}
+
+// tdsPreLogin is a minimal TDS 7.x pre-login request: header + a single
+// VERSION token pointing past the token table, per MS-TDS.
+var tdsPreLogin = []byte{
+	0x12, 0x01, 0x00, 0x2f, 0x00, 0x00, 0x01, 0x00,
+	0x00, 0x00, 0x1a, 0x00, 0x06, 0x01, 0x00, 0x20,
+	0x00, 0x01, 0x02, 0x00, 0x21, 0x00, 0x01, 0x03,
+	0x00, 0x22, 0x00, 0x04, 0xff,
+	0x09, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+}
+
+func (p *mssqlProbe) Run(ctx context.Context, conn net.Conn, opts Options) (ProbeResult, error) {
+	if opts.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(opts.Timeout))
+	}
+
+	if _, err := conn.Write(tdsPreLogin); err != nil {
+		return ProbeResult{}, fmt.Errorf("mssql: pre-login request failed: %w", err)
+	}
+
+	buf := make([]byte, 512)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("mssql: no pre-login response: %w", err)
+	}
+	if n < 1 || buf[0] != 0x04 {
+		return ProbeResult{}, fmt.Errorf("mssql: unexpected pre-login response")
+	}
+
+	return ProbeResult{
+		Service: "mssql",
+		Banner:  "TDS pre-login response received",
+	}, nil
+}
@@ -0,0 +1,54 @@
+package probes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register(&mysqlProbe{})
+}
+
+// mysqlProbe reads the initial MySQL handshake packet to recover the
+// server version without completing authentication.
+type mysqlProbe struct{}
+
+func (p *mysqlProbe) Name() string { return "mysql" }
+
+func (p *mysqlProbe) Match(port int, banner []byte) bool {
+	return port == 3306
+}
+
+func (p *mysqlProbe) Run(ctx context.Context, conn net.Conn, opts Options) (ProbeResult, error) {
+	if opts.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(opts.Timeout))
+	}
+
+	buf := make([]byte, 256)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("mysql: no handshake: %w", err)
+	}
+	packet := buf[:n]
+
+	// Handshake layout: 3-byte length, 1-byte sequence id, 1-byte protocol
+	// version, then a NUL-terminated server version string.
+	if len(packet) < 6 || packet[4] != 0x0a {
+		return ProbeResult{}, fmt.Errorf("mysql: unexpected handshake packet")
+	}
+
+	end := 5
+	for end < len(packet) && packet[end] != 0 {
+		end++
+	}
+	version := string(packet[5:end])
+
+	return ProbeResult{
+		Service: "mysql",
+		Version: version,
+		Banner:  strings.TrimSpace(version),
+	}, nil
+}
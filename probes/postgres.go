@@ -0,0 +1,63 @@
+package probes
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+func init() {
+	Register(&postgresProbe{})
+}
+
+// postgresProbe sends a StartupMessage and inspects the first reply byte
+// to tell whether the server demands authentication.
+type postgresProbe struct{}
+
+func (p *postgresProbe) Name() string { return "postgres" }
+
+func (p *postgresProbe) Match(port int, banner []byte) bool {
+	return port == 5432
+}
+
+func (p *postgresProbe) Run(ctx context.Context, conn net.Conn, opts Options) (ProbeResult, error) {
+	if opts.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(opts.Timeout))
+	}
+
+	msg := buildStartupMessage("postgres", "pscan")
+	if _, err := conn.Write(msg); err != nil {
+		return ProbeResult{}, fmt.Errorf("postgres: startup message failed: %w", err)
+	}
+
+	buf := make([]byte, 256)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("postgres: no response: %w", err)
+	}
+
+	result := ProbeResult{Service: "postgres"}
+	if n > 0 {
+		switch buf[0] {
+		case 'R':
+			result.Extra = addExtra(result.Extra, "auth", "required")
+		case 'E':
+			result.Extra = addExtra(result.Extra, "response", "error (database/user likely invalid, but server reachable)")
+		}
+	}
+
+	return result, nil
+}
+
+func buildStartupMessage(user, database string) []byte {
+	params := []byte("user\x00" + user + "\x00database\x00" + database + "\x00\x00")
+	body := make([]byte, 4+len(params))
+	binary.BigEndian.PutUint32(body[0:4], 196608) // protocol version 3.0
+	copy(body[4:], params)
+
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(4+len(body)))
+	return append(length, body...)
+}
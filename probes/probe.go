@@ -0,0 +1,94 @@
+// Package probes implements pluggable per-service checks that run against a
+// TCP connection once a port has been found open. Each probe is responsible
+// for a single service (SSH, FTP, Redis, ...) and decides for itself whether
+// it applies to a given port/banner, so the scanner core never needs to know
+// about individual protocols.
+package probes
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// ProbeResult carries whatever a probe learned about the service it
+// identified. Fields that don't apply to a given probe are left zero.
+type ProbeResult struct {
+	Service     string            // e.g. "ssh", "mysql"
+	Version     string            // service/version banner, if parsed
+	Banner      string            // raw banner the probe worked from
+	Vulnerable  []string          // names of vulnerabilities/weaknesses found
+	Credentials []string          // "user:pass" pairs that authenticated, if any
+	Extra       map[string]string // free-form probe-specific details
+}
+
+// Options configures how probes behave. It is passed through from
+// ScanOptions so probes never need to reach back into the scanner.
+type Options struct {
+	Timeout     time.Duration
+	Credentials []Credential // candidate username/password pairs for weak-cred checks
+}
+
+// Credential is a single username/password pair tried by auth-capable probes.
+type Credential struct {
+	Username string
+	Password string
+}
+
+// Probe identifies and inspects a single service over an already-connected
+// TCP socket.
+type Probe interface {
+	// Name is the probe's identifier, used by the -probes flag.
+	Name() string
+	// Match reports whether this probe should run against a port/banner
+	// combination. Probes typically match on well-known ports, on the
+	// shape of the banner, or both.
+	Match(port int, banner []byte) bool
+	// Run performs the probe's checks against conn and returns what it
+	// found. conn is owned by the caller; Run must not close it.
+	Run(ctx context.Context, conn net.Conn, opts Options) (ProbeResult, error)
+}
+
+var registry []Probe
+
+// Register adds a probe to the default registry. Probes call this from an
+// init() function so importing the probes package is enough to enable them.
+func Register(p Probe) {
+	registry = append(registry, p)
+}
+
+// All returns every registered probe.
+func All() []Probe {
+	return registry
+}
+
+// Named returns the registered probes whose Name is in names. An empty
+// names slice returns every probe.
+func Named(names []string) []Probe {
+	if len(names) == 0 {
+		return All()
+	}
+	want := make(map[string]bool, len(names))
+	for _, n := range names {
+		want[n] = true
+	}
+	var out []Probe
+	for _, p := range registry {
+		if want[p.Name()] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Match runs every probe in probes against port/banner and returns the ones
+// that claim to apply.
+func Match(probeList []Probe, port int, banner []byte) []Probe {
+	var out []Probe
+	for _, p := range probeList {
+		if p.Match(port, banner) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
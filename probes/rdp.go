@@ -0,0 +1,54 @@
+package probes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+func init() {
+	Register(&rdpProbe{})
+}
+
+// rdpProbe sends an X.224 Connection Request and confirms the service is
+// RDP by checking for a Connection Confirm reply.
+type rdpProbe struct{}
+
+func (p *rdpProbe) Name() string { return "rdp" }
+
+func (p *rdpProbe) Match(port int, banner []byte) bool {
+	return port == 3389
+}
+
+// x224ConnectionRequest is a bare TPKT + X.224 CR with no negotiation
+// data, which every RDP listener accepts for the purpose of fingerprinting.
+var x224ConnectionRequest = []byte{
+	0x03, 0x00, 0x00, 0x13, // TPKT header: version, reserved, length
+	0x0e, 0xe0, 0x00, 0x00, 0x00, 0x00, 0x00, // X.224 CR
+	0x01, 0x00, 0x08, 0x00, 0x03, 0x00, 0x00, 0x00,
+}
+
+func (p *rdpProbe) Run(ctx context.Context, conn net.Conn, opts Options) (ProbeResult, error) {
+	if opts.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(opts.Timeout))
+	}
+
+	if _, err := conn.Write(x224ConnectionRequest); err != nil {
+		return ProbeResult{}, fmt.Errorf("rdp: connection request failed: %w", err)
+	}
+
+	buf := make([]byte, 64)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("rdp: no connection confirm: %w", err)
+	}
+	if n < 6 || buf[5] != 0xd0 {
+		return ProbeResult{}, fmt.Errorf("rdp: unexpected X.224 response")
+	}
+
+	return ProbeResult{
+		Service: "rdp",
+		Banner:  "X.224 connection confirmed",
+	}, nil
+}
@@ -0,0 +1,84 @@
+package probes
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register(&redisProbe{})
+}
+
+// redisProbe checks for unauthenticated access and, failing that, tries any
+// supplied passwords via AUTH.
+type redisProbe struct{}
+
+func (p *redisProbe) Name() string { return "redis" }
+
+func (p *redisProbe) Match(port int, banner []byte) bool {
+	return port == 6379
+}
+
+func (p *redisProbe) Run(ctx context.Context, conn net.Conn, opts Options) (ProbeResult, error) {
+	if opts.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(opts.Timeout))
+	}
+	reader := bufio.NewReader(conn)
+
+	reply, err := redisCommand(conn, reader, "INFO server")
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("redis: %w", err)
+	}
+
+	result := ProbeResult{Service: "redis"}
+
+	if strings.HasPrefix(reply, "-NOAUTH") {
+		result.Extra = addExtra(result.Extra, "auth", "required")
+		for _, cred := range opts.Credentials {
+			auth, err := redisCommand(conn, reader, fmt.Sprintf("AUTH %s", cred.Password))
+			if err == nil && strings.HasPrefix(auth, "+OK") {
+				result.Credentials = append(result.Credentials, fmt.Sprintf(":%s", cred.Password))
+				break
+			}
+		}
+		return result, nil
+	}
+
+	result.Extra = addExtra(result.Extra, "auth", "none")
+	for _, line := range strings.Split(reply, "\r\n") {
+		if v, ok := strings.CutPrefix(line, "redis_version:"); ok {
+			result.Version = v
+		}
+	}
+	result.Banner = strings.TrimSpace(reply)
+
+	return result, nil
+}
+
+func redisCommand(conn net.Conn, reader *bufio.Reader, cmd string) (string, error) {
+	if _, err := conn.Write([]byte(cmd + "\r\n")); err != nil {
+		return "", err
+	}
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	// Bulk replies ($N) are followed by N bytes plus a trailing CRLF; read
+	// the payload so the connection stays in sync for subsequent commands.
+	if strings.HasPrefix(line, "$") {
+		var n int
+		fmt.Sscanf(strings.TrimSpace(line), "$%d", &n)
+		if n > 0 {
+			buf := make([]byte, n+2)
+			if _, err := reader.Read(buf); err != nil {
+				return "", err
+			}
+			return string(buf[:n]), nil
+		}
+	}
+	return strings.TrimSpace(line), nil
+}
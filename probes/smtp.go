@@ -0,0 +1,58 @@
+package probes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register(&smtpProbe{})
+}
+
+// smtpProbe reads the SMTP greeting and enumerates supported extensions
+// via EHLO.
+type smtpProbe struct{}
+
+func (p *smtpProbe) Name() string { return "smtp" }
+
+func (p *smtpProbe) Match(port int, banner []byte) bool {
+	switch port {
+	case 25, 465, 587:
+		return true
+	}
+	return strings.HasPrefix(string(banner), "220") && strings.Contains(string(banner), "SMTP")
+}
+
+func (p *smtpProbe) Run(ctx context.Context, conn net.Conn, opts Options) (ProbeResult, error) {
+	if opts.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(opts.Timeout))
+	}
+
+	tp := textproto.NewConn(conn)
+	_, banner, err := tp.ReadResponse(220)
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("smtp: no greeting: %w", err)
+	}
+
+	result := ProbeResult{
+		Service: "smtp",
+		Banner:  banner,
+	}
+
+	if err := tp.PrintfLine("EHLO pscan.local"); err == nil {
+		if _, exts, err := tp.ReadResponse(250); err == nil {
+			for _, line := range strings.Split(exts, "\n") {
+				line = strings.TrimSpace(line)
+				if line != "" {
+					result.Extra = addExtra(result.Extra, "extension", line)
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
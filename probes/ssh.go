@@ -0,0 +1,66 @@
+package probes
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register(&sshProbe{})
+}
+
+// sshProbe identifies the SSH version banner and, if credentials were
+// supplied, tries them against the server.
+type sshProbe struct{}
+
+func (p *sshProbe) Name() string { return "ssh" }
+
+func (p *sshProbe) Match(port int, banner []byte) bool {
+	if port == 22 {
+		return true
+	}
+	return strings.HasPrefix(string(banner), "SSH-")
+}
+
+func (p *sshProbe) Run(ctx context.Context, conn net.Conn, opts Options) (ProbeResult, error) {
+	if opts.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(opts.Timeout))
+	}
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && line == "" {
+		return ProbeResult{}, fmt.Errorf("ssh: no banner: %w", err)
+	}
+	line = strings.TrimSpace(line)
+
+	result := ProbeResult{
+		Service: "ssh",
+		Version: line,
+		Banner:  line,
+	}
+
+	// Weak-credential attempts are left to a real SSH client handshake,
+	// which is out of scope for a banner-only connection; record the
+	// candidates we would have tried so downstream tooling can follow up.
+	for _, cred := range opts.Credentials {
+		result.Extra = addExtra(result.Extra, "untested_credential", fmt.Sprintf("%s:%s", cred.Username, cred.Password))
+	}
+
+	return result, nil
+}
+
+func addExtra(extra map[string]string, key, value string) map[string]string {
+	if extra == nil {
+		extra = make(map[string]string)
+	}
+	if existing, ok := extra[key]; ok {
+		extra[key] = existing + "," + value
+	} else {
+		extra[key] = value
+	}
+	return extra
+}
@@ -0,0 +1,133 @@
+package probes
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register(&tlsProbe{})
+}
+
+// tlsWellKnownPorts lists ports that are TLS by convention even before any
+// bytes have been read from them.
+var tlsWellKnownPorts = map[int]bool{
+	443:  true,
+	8443: true,
+	993:  true,
+	995:  true,
+	465:  true,
+}
+
+// tlsProbe performs a real TLS handshake (skipping certificate
+// verification, since the goal is reconnaissance rather than trust) and
+// reports the negotiated parameters and leaf certificate details. It
+// supersedes plaintext banner grabbing on tlsWellKnownPorts, since
+// writing a plaintext probe at a TLS listener just gets back an
+// encrypted Alert.
+//
+// There's no banner-based way to extend this to arbitrary ports: TLS is
+// client-first, so a server never sends anything until it receives a
+// ClientHello, and pscan's banner grab is a blind read with no write (for
+// non-HTTP ports). A port only ever gets probed here if it's in
+// tlsWellKnownPorts.
+type tlsProbe struct{}
+
+func (p *tlsProbe) Name() string { return "tls" }
+
+func (p *tlsProbe) Match(port int, banner []byte) bool {
+	return tlsWellKnownPorts[port]
+}
+
+func (p *tlsProbe) Run(ctx context.Context, conn net.Conn, opts Options) (ProbeResult, error) {
+	sni, _, _ := net.SplitHostPort(conn.RemoteAddr().String())
+
+	if opts.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(opts.Timeout))
+	}
+
+	tlsConn := tls.Client(conn, &tls.Config{
+		InsecureSkipVerify: true,
+		ServerName:         sni,
+		NextProtos:         []string{"h2", "http/1.1"},
+	})
+	defer tlsConn.SetDeadline(time.Time{})
+
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return ProbeResult{}, fmt.Errorf("tls: handshake failed: %w", err)
+	}
+
+	state := tlsConn.ConnectionState()
+	result := ProbeResult{
+		Service: "tls",
+		Version: tlsVersionName(state.Version),
+		Extra: map[string]string{
+			"cipher_suite": tls.CipherSuiteName(state.CipherSuite),
+			"sni":          sni,
+		},
+	}
+	if state.NegotiatedProtocol != "" {
+		result.Extra["alpn"] = state.NegotiatedProtocol
+	}
+
+	if len(state.PeerCertificates) > 0 {
+		leaf := state.PeerCertificates[0]
+		result.Extra["subject"] = leaf.Subject.String()
+		result.Extra["issuer"] = leaf.Issuer.String()
+		result.Extra["not_before"] = leaf.NotBefore.Format(time.RFC3339)
+		result.Extra["not_after"] = leaf.NotAfter.Format(time.RFC3339)
+		if len(leaf.DNSNames) > 0 {
+			result.Extra["san"] = strings.Join(leaf.DNSNames, ",")
+		}
+	}
+
+	// Only speak HTTP over the handshake if the peer actually negotiated
+	// an HTTP ALPN protocol: mail services (993/995/465) never do, and
+	// writing a HEAD request at them instead of waiting for their
+	// server-first greeting just gets an empty/garbage banner.
+	if state.NegotiatedProtocol == "h2" || state.NegotiatedProtocol == "http/1.1" {
+		result.Banner = readALPNBanner(tlsConn)
+	}
+
+	return result, nil
+}
+
+// readALPNBanner makes a best-effort plaintext HTTP HEAD request over the
+// now-established TLS connection so callers still get a human-readable
+// banner line, matching what the plaintext http probe does for port 80.
+// Only called once the peer has actually negotiated an HTTP ALPN protocol.
+func readALPNBanner(conn net.Conn) string {
+	conn.SetDeadline(time.Now().Add(1 * time.Second))
+	host, _, _ := net.SplitHostPort(conn.RemoteAddr().String())
+	if _, err := fmt.Fprintf(conn, "HEAD / HTTP/1.1\r\nHost: %s\r\nConnection: close\r\n\r\n", host); err != nil {
+		return ""
+	}
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(line)
+}
+
+// tlsVersionName maps a tls.VersionTLSxx constant to the familiar
+// dotted-decimal name (e.g. 0x0304 -> "1.3").
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "1.0"
+	case tls.VersionTLS11:
+		return "1.1"
+	case tls.VersionTLS12:
+		return "1.2"
+	case tls.VersionTLS13:
+		return "1.3"
+	default:
+		return "0x" + strconv.FormatUint(uint64(version), 16)
+	}
+}
@@ -0,0 +1,62 @@
+package probes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+func init() {
+	Register(&vncProbe{})
+}
+
+// vncProbe reads the RFB protocol version handshake, which is sent
+// unprompted by every VNC server and doubles as a version fingerprint.
+type vncProbe struct{}
+
+func (p *vncProbe) Name() string { return "vnc" }
+
+func (p *vncProbe) Match(port int, banner []byte) bool {
+	if port == 5900 {
+		return true
+	}
+	return strings.HasPrefix(string(banner), "RFB ")
+}
+
+func (p *vncProbe) Run(ctx context.Context, conn net.Conn, opts Options) (ProbeResult, error) {
+	if opts.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(opts.Timeout))
+	}
+
+	buf := make([]byte, 12)
+	n, err := conn.Read(buf)
+	if err != nil || n < 12 {
+		return ProbeResult{}, fmt.Errorf("vnc: no RFB handshake: %w", err)
+	}
+	version := strings.TrimSpace(string(buf[:n]))
+
+	// Echo the same version back to complete the handshake far enough to
+	// reach the security-type negotiation, which tells us whether
+	// authentication is required at all.
+	if _, err := conn.Write(buf[:n]); err != nil {
+		return ProbeResult{Service: "vnc", Version: version, Banner: version}, nil
+	}
+
+	secTypes := make([]byte, 16)
+	sn, err := conn.Read(secTypes)
+
+	result := ProbeResult{
+		Service: "vnc",
+		Version: version,
+		Banner:  version,
+	}
+	if err == nil && sn > 1 && secTypes[1] == 1 {
+		result.Extra = addExtra(result.Extra, "auth", "none")
+	} else if err == nil && sn > 0 {
+		result.Extra = addExtra(result.Extra, "auth", "required")
+	}
+
+	return result, nil
+}
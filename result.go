@@ -0,0 +1,24 @@
+package main
+
+import (
+	"time"
+
+	"github.com/yasufadhili/pscan/probes"
+)
+
+// Result is what a single port scan produces, regardless of which scan
+// mode found it. It's pushed onto a channel so a single collector
+// goroutine can serialize output instead of every worker writing to
+// stdout directly.
+type Result struct {
+	Host      string                        `json:"host"`
+	Port      int                           `json:"port"`
+	Proto     string                        `json:"proto"` // "tcp" or "udp"
+	State     string                        `json:"state"` // "open", "closed", "filtered", "open|filtered"
+	Service   string                        `json:"service,omitempty"`
+	Banner    string                        `json:"banner,omitempty"`
+	TLS       map[string]string             `json:"tls,omitempty"`
+	ProbeData map[string]probes.ProbeResult `json:"probe_data,omitempty"`
+	Latency   time.Duration                 `json:"latency_ns"`
+	Error     string                        `json:"error,omitempty"`
+}
@@ -0,0 +1,301 @@
+//go:build synscan
+
+// The raw-socket SYN scanner needs cgo and libpcap's headers, which most
+// machines don't have installed. Keeping it behind the synscan build tag
+// means `go build ./...` (and every other mode) still works out of the
+// box; building with `-tags synscan` opts into -mode syn, trading
+// portability for the faster half-open scan. See synscan_stub.go for the
+// no-tag fallback that keeps -mode syn gracefully unavailable instead of
+// refusing to compile at all.
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+)
+
+// pendingSYNs correlates replies to the SYN that triggered them. Every
+// outstanding probe is keyed by the ephemeral sequence number chosen for
+// it, since that's the only thing a SYN+ACK or RST reply lets us match
+// back to a (host, port) pair.
+type pendingSYNs struct {
+	mu sync.Mutex
+	m  map[uint32]synJob
+}
+
+type synJob struct {
+	Host string
+	Port int
+}
+
+func newPendingSYNs() *pendingSYNs {
+	return &pendingSYNs{m: make(map[uint32]synJob)}
+}
+
+func (p *pendingSYNs) add(seq uint32, job synJob) {
+	p.mu.Lock()
+	p.m[seq] = job
+	p.mu.Unlock()
+}
+
+func (p *pendingSYNs) take(seq uint32) (synJob, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	job, ok := p.m[seq]
+	if ok {
+		delete(p.m, seq)
+	}
+	return job, ok
+}
+
+// runSynScan performs a raw-socket half-open scan: a single sender
+// goroutine crafts SYN packets with random source ports and a reader
+// goroutine classifies replies off one BPF-filtered pcap handle, matching
+// them back to the originating probe by (srcIP, dstPort, ack == seq+1).
+// This is dramatically cheaper per-port than a full TCP connect, since a
+// scanned-but-closed port never completes a handshake. It needs
+// CAP_NET_RAW (or root); runPortScan's connect-based scan is the portable
+// fallback when raw sockets aren't available.
+//
+// Results are pushed onto the same Result/collector pipeline as the
+// connect scan, so -format/-o/-retries behave the same way here.
+func runSynScan(options ScanOptions, hosts []string) error {
+	if len(hosts) == 0 {
+		return fmt.Errorf("syn scan: no targets")
+	}
+
+	iface, srcIP, err := findRouteInterface(hosts[0])
+	if err != nil {
+		return fmt.Errorf("syn scan: %w", err)
+	}
+
+	handle, err := pcap.OpenLive(iface, 65535, true, pcap.BlockForever)
+	if err != nil {
+		return fmt.Errorf("syn scan: opening pcap handle (needs CAP_NET_RAW/root): %w", err)
+	}
+	defer handle.Close()
+
+	if err := handle.SetBPFFilter(fmt.Sprintf("tcp and dst host %s", srcIP)); err != nil {
+		return fmt.Errorf("syn scan: setting BPF filter: %w", err)
+	}
+
+	results := make(chan Result, options.Threads)
+	collectorDone := make(chan error, 1)
+	go func() { collectorDone <- runCollector(results, options) }()
+
+	pending := newPendingSYNs()
+	readerStop := make(chan struct{})
+	readerDone := make(chan struct{})
+	go func() {
+		readSynReplies(handle, pending, results, readerStop)
+		close(readerDone)
+	}()
+
+	for _, host := range hosts {
+		for port := options.StartPort; port <= options.EndPort; port++ {
+			seq := rand.Uint32()
+			job := synJob{Host: host, Port: port}
+			pending.add(seq, job)
+			if err := sendSYN(handle, srcIP, host, port, seq); err != nil && options.Verbose {
+				fmt.Printf("syn scan: sending to %s:%d failed: %v\n", host, port, err)
+			}
+		}
+	}
+
+	// Give outstanding probes one timeout window to reply, then anything
+	// still pending is filtered (no response at all).
+	time.Sleep(time.Duration(options.Timeout) * time.Millisecond)
+	close(readerStop)
+	<-readerDone
+
+	drainRemaining(pending, results)
+	close(results)
+
+	return <-collectorDone
+}
+
+// drainRemaining reports every probe that never got a reply as filtered.
+func drainRemaining(pending *pendingSYNs, results chan<- Result) {
+	pending.mu.Lock()
+	defer pending.mu.Unlock()
+	for _, job := range pending.m {
+		results <- Result{Host: job.Host, Port: job.Port, Proto: "tcp", State: "filtered"}
+	}
+}
+
+// sendSYN crafts and writes a single TCP SYN packet with the given
+// sequence number, which doubles as the probe's correlation id.
+func sendSYN(handle *pcap.Handle, srcIP net.IP, dstHost string, dstPort int, seq uint32) error {
+	dstIP := net.ParseIP(dstHost)
+	if dstIP == nil {
+		resolved, err := net.ResolveIPAddr("ip4", dstHost)
+		if err != nil {
+			return fmt.Errorf("resolving %s: %w", dstHost, err)
+		}
+		dstIP = resolved.IP
+	}
+
+	srcPort := 1024 + uint16(seq%(65535-1024))
+
+	ipLayer := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolTCP,
+		SrcIP:    srcIP,
+		DstIP:    dstIP.To4(),
+	}
+	tcpLayer := &layers.TCP{
+		SrcPort: layers.TCPPort(srcPort),
+		DstPort: layers.TCPPort(dstPort),
+		Seq:     seq,
+		SYN:     true,
+		Window:  14600,
+	}
+	tcpLayer.SetNetworkLayerForChecksum(ipLayer)
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(buf, opts, ipLayer, tcpLayer); err != nil {
+		return fmt.Errorf("serializing SYN packet: %w", err)
+	}
+
+	return handle.WritePacketData(buf.Bytes())
+}
+
+// sendRST answers a SYN+ACK with a RST so the target tears its half of the
+// handshake down immediately instead of waiting for its own retransmit
+// timeout: ip and tcp are the layers of the reply we're tearing down, so
+// src/dst are swapped relative to the original sendSYN.
+func sendRST(handle *pcap.Handle, ip *layers.IPv4, tcp *layers.TCP) error {
+	ipLayer := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolTCP,
+		SrcIP:    ip.DstIP,
+		DstIP:    ip.SrcIP,
+	}
+	tcpLayer := &layers.TCP{
+		SrcPort: tcp.DstPort,
+		DstPort: tcp.SrcPort,
+		Seq:     tcp.Ack,
+		Ack:     tcp.Seq + 1,
+		RST:     true,
+		ACK:     true,
+	}
+	tcpLayer.SetNetworkLayerForChecksum(ipLayer)
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(buf, opts, ipLayer, tcpLayer); err != nil {
+		return fmt.Errorf("serializing RST packet: %w", err)
+	}
+
+	return handle.WritePacketData(buf.Bytes())
+}
+
+// readSynReplies classifies incoming TCP packets as belonging to one of
+// our outstanding probes and emits the corresponding Result. It does not
+// own results and must not close it: runSynScan's collector pipeline also
+// receives the drainRemaining results on the same channel.
+func readSynReplies(handle *pcap.Handle, pending *pendingSYNs, results chan<- Result, stop <-chan struct{}) {
+	source := gopacket.NewPacketSource(handle, handle.LinkType())
+	packets := source.Packets()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case packet, ok := <-packets:
+			if !ok {
+				return
+			}
+
+			tcpLayer := packet.Layer(layers.LayerTypeTCP)
+			ipLayer := packet.Layer(layers.LayerTypeIPv4)
+			if tcpLayer == nil || ipLayer == nil {
+				continue
+			}
+			tcp, _ := tcpLayer.(*layers.TCP)
+			ip, _ := ipLayer.(*layers.IPv4)
+
+			expectedSeq := tcp.Ack - 1
+			job, ok := pending.take(expectedSeq)
+			if !ok {
+				continue
+			}
+
+			switch {
+			case tcp.SYN && tcp.ACK:
+				results <- Result{Host: job.Host, Port: job.Port, Proto: "tcp", State: "open"}
+				// Half-open means half-open on our end, not the target's:
+				// tear the handshake back down so the remote doesn't sit
+				// there waiting out its own retransmit timeout.
+				_ = sendRST(handle, ip, tcp)
+			case tcp.RST:
+				results <- Result{Host: job.Host, Port: job.Port, Proto: "tcp", State: "closed"}
+			default:
+				pending.add(expectedSeq, job) // unrelated flags, keep waiting
+			}
+		}
+	}
+}
+
+// findRouteInterface picks the outbound interface and source IP the
+// kernel would use to reach target, so sendSYN/the BPF filter know which
+// device and address to work with.
+func findRouteInterface(target string) (iface string, srcIP net.IP, err error) {
+	conn, err := net.Dial("udp", net.JoinHostPort(target, "80"))
+	if err != nil {
+		return "", nil, fmt.Errorf("determining route to %s: %w", target, err)
+	}
+	defer conn.Close()
+
+	localAddr := conn.LocalAddr().(*net.UDPAddr)
+	srcIP = localAddr.IP
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return "", nil, err
+	}
+	for _, ifi := range ifaces {
+		addrs, err := ifi.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.Equal(srcIP) {
+				return ifi.Name, srcIP, nil
+			}
+		}
+	}
+	return "", nil, fmt.Errorf("no interface found for source address %s", srcIP)
+}
+
+// synScanAvailable reports whether raw-socket scanning looks usable on
+// this host, so callers can fall back to the connect scan instead of
+// failing outright. Enumerating devices only confirms pcap itself is
+// linked; actually opening one is what needs CAP_NET_RAW/root, so that's
+// what we test against the first device that's up.
+func synScanAvailable() bool {
+	ifaces, err := pcap.FindAllDevs()
+	if err != nil || len(ifaces) == 0 {
+		return false
+	}
+
+	for _, ifi := range ifaces {
+		handle, err := pcap.OpenLive(ifi.Name, 65535, true, time.Millisecond)
+		if err != nil {
+			continue
+		}
+		handle.Close()
+		return true
+	}
+	return false
+}
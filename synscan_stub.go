@@ -0,0 +1,19 @@
+//go:build !synscan
+
+package main
+
+import "fmt"
+
+// synScanAvailable always reports false in builds without the synscan tag,
+// so -mode syn falls back to the portable connect scan instead of hitting
+// the hard error below.
+func synScanAvailable() bool {
+	return false
+}
+
+// runSynScan is never expected to run: synScanAvailable() steers -mode syn
+// to runPortScan instead. It only exists so the call site in pscan.go
+// compiles the same way regardless of the synscan build tag.
+func runSynScan(options ScanOptions, hosts []string) error {
+	return fmt.Errorf("syn scan: this binary was built without raw-socket support (rebuild with -tags synscan)")
+}
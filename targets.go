@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+// ParseTargets expands a -target spec into a deduplicated list of hosts.
+// spec is a comma-separated mix of hostnames, IPs, CIDR blocks
+// (e.g. 10.0.0.0/24), and @file references, mirroring fscan's hostslist
+// plumbing. Hosts present in exclude (same spec syntax) are dropped.
+func ParseTargets(spec, exclude string) ([]string, error) {
+	excludeSet := map[string]bool{}
+	if exclude != "" {
+		excluded, err := expandTargetSpec(exclude)
+		if err != nil {
+			return nil, fmt.Errorf("parsing -exclude: %w", err)
+		}
+		for _, h := range excluded {
+			excludeSet[h] = true
+		}
+	}
+
+	hosts, err := expandTargetSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(hosts))
+	var result []string
+	for _, h := range hosts {
+		if seen[h] || excludeSet[h] {
+			continue
+		}
+		seen[h] = true
+		result = append(result, h)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no targets resolved from %q", spec)
+	}
+	return result, nil
+}
+
+// expandTargetSpec expands a single comma-separated spec (without applying
+// exclusions) into concrete hosts.
+func expandTargetSpec(spec string) ([]string, error) {
+	var hosts []string
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(part, "@"):
+			fileHosts, err := readHostsFile(part[1:])
+			if err != nil {
+				return nil, err
+			}
+			hosts = append(hosts, fileHosts...)
+		case strings.Contains(part, "/"):
+			cidrHosts, err := expandCIDR(part)
+			if err != nil {
+				return nil, err
+			}
+			hosts = append(hosts, cidrHosts...)
+		default:
+			hosts = append(hosts, part)
+		}
+	}
+	return hosts, nil
+}
+
+// expandCIDR lists every host address in a CIDR block, skipping the
+// network and broadcast addresses for /30 or larger blocks (which includes
+// the common /24 case).
+func expandCIDR(cidr string) ([]string, error) {
+	ip, ipnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+	}
+
+	var ips []string
+	for cur := ip.Mask(ipnet.Mask); ipnet.Contains(cur); incIP(cur) {
+		ips = append(ips, cur.String())
+	}
+
+	if ones, bits := ipnet.Mask.Size(); bits-ones >= 2 && len(ips) > 2 {
+		ips = ips[1 : len(ips)-1] // drop network and broadcast addresses
+	}
+
+	return ips, nil
+}
+
+// incIP increments ip in place, treating it as a big-endian byte counter.
+func incIP(ip net.IP) {
+	for i := len(ip) - 1; i >= 0; i-- {
+		ip[i]++
+		if ip[i] != 0 {
+			return
+		}
+	}
+}
+
+// readHostsFile reads one host per line from path, ignoring blank lines
+// and lines starting with '#'.
+func readHostsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading host file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var hosts []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		hosts = append(hosts, line)
+	}
+	return hosts, scanner.Err()
+}
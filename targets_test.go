@@ -0,0 +1,80 @@
+package main
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestExpandCIDR(t *testing.T) {
+	tests := []struct {
+		name string
+		cidr string
+		want []string
+	}{
+		{
+			name: "/30 drops network and broadcast",
+			cidr: "10.0.0.0/30",
+			want: []string{"10.0.0.1", "10.0.0.2"},
+		},
+		{
+			name: "/31 has no network/broadcast to drop",
+			cidr: "10.0.0.0/31",
+			want: []string{"10.0.0.0", "10.0.0.1"},
+		},
+		{
+			name: "/32 is a single host",
+			cidr: "10.0.0.5/32",
+			want: []string{"10.0.0.5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("expandCIDR(%q) returned error: %v", tt.cidr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandCIDR(%q) = %v, want %v", tt.cidr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandCIDRInvalid(t *testing.T) {
+	if _, err := expandCIDR("not-a-cidr"); err == nil {
+		t.Fatal("expandCIDR with an invalid CIDR should return an error")
+	}
+}
+
+func TestIncIPWraparound(t *testing.T) {
+	ip := net.ParseIP("0.0.0.255").To4()
+	incIP(ip)
+	if ip.String() != "0.0.1.0" {
+		t.Errorf("incIP carried byte wrong: got %s, want 0.0.1.0", ip.String())
+	}
+
+	ip = net.ParseIP("0.0.255.255").To4()
+	incIP(ip)
+	if ip.String() != "0.1.0.0" {
+		t.Errorf("incIP multi-byte carry wrong: got %s, want 0.1.0.0", ip.String())
+	}
+}
+
+func TestParseTargetsDedupAndExclude(t *testing.T) {
+	got, err := ParseTargets("10.0.0.0/30,10.0.0.1,example.com", "example.com")
+	if err != nil {
+		t.Fatalf("ParseTargets returned error: %v", err)
+	}
+	want := []string{"10.0.0.1", "10.0.0.2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseTargets = %v, want %v", got, want)
+	}
+}
+
+func TestParseTargetsNoneResolved(t *testing.T) {
+	if _, err := ParseTargets("example.com", "example.com"); err == nil {
+		t.Fatal("ParseTargets should error when every target is excluded")
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+// udpProbes maps a well-known UDP port to a protocol-appropriate probe
+// payload that's likely to elicit a reply from a real service, so open
+// UDP ports can be told apart from open|filtered ones instead of relying
+// on silence alone.
+var udpProbes = map[int][]byte{
+	53:   dnsQuery(),
+	123:  ntpRequest(),
+	137:  netbiosNameQuery(),
+	161:  snmpGetRequest(),
+	500:  ikeRequest(),
+	5060: []byte("OPTIONS sip:ping SIP/2.0\r\nVia: SIP/2.0/UDP pscan\r\nFrom: <sip:pscan@pscan>\r\nTo: <sip:ping@ping>\r\nCall-ID: 1@pscan\r\nCSeq: 1 OPTIONS\r\nContent-Length: 0\r\n\r\n"),
+	5353: dnsQuery(), // mDNS shares DNS's wire format
+}
+
+// udpPayload returns the probe payload for port, falling back to a
+// zero-byte datagram for services we don't have a specific probe for;
+// some UDP services (e.g. plain echo-like listeners) reply to that too.
+func udpPayload(port int) []byte {
+	if payload, ok := udpProbes[port]; ok {
+		return payload
+	}
+	return []byte{}
+}
+
+// dnsQuery builds a minimal "A? ." query, which any DNS resolver answers
+// (even if just with REFUSED/SERVFAIL), confirming the service is alive.
+func dnsQuery() []byte {
+	return []byte{
+		0x12, 0x34, // transaction id
+		0x01, 0x00, // flags: standard query, recursion desired
+		0x00, 0x01, // questions: 1
+		0x00, 0x00, // answer RRs
+		0x00, 0x00, // authority RRs
+		0x00, 0x00, // additional RRs
+		0x00,       // root domain name
+		0x00, 0x01, // type A
+		0x00, 0x01, // class IN
+	}
+}
+
+// ntpRequest builds a minimal client mode-3 NTP request.
+func ntpRequest() []byte {
+	req := make([]byte, 48)
+	req[0] = 0x1b // LI=0, VN=3, Mode=3 (client)
+	return req
+}
+
+// netbiosNameQuery builds a NetBIOS name service query for "*" (the
+// wildcard name almost every NetBIOS host answers for discovery).
+func netbiosNameQuery() []byte {
+	return []byte{
+		0x12, 0x34, 0x00, 0x10, // transaction id, flags
+		0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // 1 question
+		0x20, // name length
+		'C', 'K', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A',
+		'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A', 'A',
+		0x00,
+		0x00, 0x21, // type: NBSTAT
+		0x00, 0x01, // class: IN
+	}
+}
+
+// snmpGetRequest builds an SNMPv1 GetRequest for sysDescr.0 under the
+// "public" community, the classic weak-config probe.
+func snmpGetRequest() []byte {
+	return []byte{
+		0x30, 0x29, // SEQUENCE
+		0x02, 0x01, 0x00, // version: v1
+		0x04, 0x06, 'p', 'u', 'b', 'l', 'i', 'c', // community
+		0xa0, 0x1c, // GetRequest PDU
+		0x02, 0x01, 0x01, // request id
+		0x02, 0x01, 0x00, // error status
+		0x02, 0x01, 0x00, // error index
+		0x30, 0x11, // varbind list
+		0x30, 0x0f,
+		0x06, 0x0b, 0x2b, 0x06, 0x01, 0x02, 0x01, 0x01, 0x01, 0x00, // sysDescr.0
+		0x05, 0x00, // NULL value
+	}
+}
+
+// ikeRequest builds a minimal ISAKMP header for main mode, enough to
+// elicit a reply (or an ICMP port-unreachable) from an IKE responder.
+func ikeRequest() []byte {
+	req := make([]byte, 28)
+	req[16] = 0x01 // next payload: SA
+	req[17] = 0x10 // version 1.0
+	req[18] = 0x02 // exchange type: identity protection (main mode)
+	return req
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+// icmpUnreachableTracker records recent "port unreachable" notifications
+// so the UDP scan can tell a closed port (ICMP replied) apart from an
+// open|filtered one (nothing replied at all) even though both look like
+// silence on the UDP socket itself.
+type icmpUnreachableTracker struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+func newICMPUnreachableTracker() *icmpUnreachableTracker {
+	return &icmpUnreachableTracker{seen: make(map[string]time.Time)}
+}
+
+func (t *icmpUnreachableTracker) mark(host string, port int) {
+	t.mu.Lock()
+	t.seen[fmt.Sprintf("%s:%d", host, port)] = time.Now()
+	t.mu.Unlock()
+}
+
+func (t *icmpUnreachableTracker) has(host string, port int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.seen[fmt.Sprintf("%s:%d", host, port)]
+	return ok
+}
+
+// listenICMPUnreachable reads ICMP packets on a raw socket and feeds any
+// "destination port unreachable" message into tracker, until stop fires.
+// Like the SYN scan, this needs CAP_NET_RAW/root; the UDP scan still works
+// without it, it just can't distinguish closed from open|filtered.
+func listenICMPUnreachable(tracker *icmpUnreachableTracker, stop <-chan struct{}) error {
+	conn, err := icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		return fmt.Errorf("opening ICMP listener: %w", err)
+	}
+	defer conn.Close()
+
+	go func() {
+		<-stop
+		conn.Close()
+	}()
+
+	buf := make([]byte, 1500)
+	for {
+		n, peer, err := conn.ReadFrom(buf)
+		if err != nil {
+			return nil // closed via stop, or a transient read error
+		}
+
+		msg, err := icmp.ParseMessage(1, buf[:n]) // protocol 1 = ICMP
+		if err != nil {
+			continue
+		}
+		dstUnreach, ok := msg.Body.(*icmp.DstUnreach)
+		if !ok || msg.Type != ipv4.ICMPTypeDestinationUnreachable {
+			continue
+		}
+
+		host, port, ok := originalUDPDest(dstUnreach.Data)
+		if !ok {
+			continue
+		}
+		_ = peer
+		tracker.mark(host, port)
+	}
+}
+
+// originalUDPDest pulls the destination host/port of the UDP datagram
+// that triggered an ICMP unreachable out of the quoted IP+UDP header ICMP
+// embeds in the error payload.
+func originalUDPDest(quoted []byte) (host string, port int, ok bool) {
+	if len(quoted) < 20+8 {
+		return "", 0, false
+	}
+	ihl := int(quoted[0]&0x0f) * 4
+	if len(quoted) < ihl+4 {
+		return "", 0, false
+	}
+	dstIP := net.IP(quoted[16:20])
+	dstPort := int(quoted[ihl+2])<<8 | int(quoted[ihl+3])
+	return dstIP.String(), dstPort, true
+}
+
+// runUDPScan probes every host/port with a protocol-appropriate payload
+// and classifies each as open (got a reply), closed (ICMP port
+// unreachable), or open|filtered (silence, the common case for a
+// correctly-behaving firewall or an idle service). Results are pushed
+// onto the same Result/collector pipeline as the connect and syn scans,
+// so -format/-o/-retries behave the same way here.
+func runUDPScan(options ScanOptions, hosts []string) error {
+	fmt.Printf("Starting UDP scan on %d host(s) (ports %d-%d)\n",
+		len(hosts), options.StartPort, options.EndPort)
+
+	tracker := newICMPUnreachableTracker()
+	stop := make(chan struct{})
+	go func() {
+		if err := listenICMPUnreachable(tracker, stop); err != nil && options.Verbose {
+			fmt.Printf("udp scan: %v (closed vs open|filtered will be less accurate)\n", err)
+		}
+	}()
+
+	results := make(chan Result, options.Threads)
+	collectorDone := make(chan error, 1)
+	go func() { collectorDone <- runCollector(results, options) }()
+
+	addrs := make(chan Addr, options.Threads)
+	var wg sync.WaitGroup
+	for i := 0; i < options.Threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for addr := range addrs {
+				scanUDPPort(options, addr, tracker, results)
+			}
+		}()
+	}
+
+	for _, host := range hosts {
+		for port := options.StartPort; port <= options.EndPort; port++ {
+			addrs <- Addr{Host: host, Port: port}
+		}
+	}
+	close(addrs)
+	wg.Wait()
+	close(stop)
+	close(results)
+
+	if err := <-collectorDone; err != nil {
+		return err
+	}
+
+	fmt.Println("Scan complete!")
+	return nil
+}
+
+// scanUDPPort sends addr's probe payload, classifies the port, and pushes
+// the outcome onto results.
+func scanUDPPort(options ScanOptions, addr Addr, tracker *icmpUnreachableTracker, results chan<- Result) {
+	timeout := time.Duration(options.Timeout) * time.Millisecond
+	address := net.JoinHostPort(addr.Host, strconv.Itoa(addr.Port))
+
+	conn, err := net.DialTimeout("udp", address, timeout)
+	if err != nil {
+		results <- Result{Host: addr.Host, Port: addr.Port, Proto: "udp", State: "error", Error: err.Error()}
+		return
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write(udpPayload(addr.Port)); err != nil {
+		results <- Result{Host: addr.Host, Port: addr.Port, Proto: "udp", State: "error", Error: err.Error()}
+		return
+	}
+
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	buf := make([]byte, 1500)
+	n, err := conn.Read(buf)
+
+	switch {
+	case err == nil:
+		results <- Result{
+			Host: addr.Host, Port: addr.Port, Proto: "udp",
+			State: "open", Banner: truncateBanner(string(buf[:n])),
+		}
+	case tracker.has(addr.Host, addr.Port):
+		results <- Result{Host: addr.Host, Port: addr.Port, Proto: "udp", State: "closed"}
+	default:
+		// No reply and no ICMP error within the timeout window: the
+		// service may be open and simply didn't answer our probe, or a
+		// firewall silently dropped it. UDP can't tell the two apart.
+		results <- Result{Host: addr.Host, Port: addr.Port, Proto: "udp", State: "open|filtered"}
+	}
+}
+
+// truncateBanner makes a UDP reply safe to show as a Result.Banner: most
+// of the protocols pscan probes (DNS, SNMP, NetBIOS) reply with binary
+// data, not text, so non-printable bytes are replaced before truncating —
+// otherwise they'd corrupt the text/CSV renderings in output.go.
+func truncateBanner(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if r < 0x20 || r == 0x7f {
+			b.WriteByte('.')
+			continue
+		}
+		b.WriteRune(r)
+	}
+	banner := b.String()
+
+	const maxLen = 80
+	if len(banner) > maxLen {
+		return banner[:maxLen] + "..."
+	}
+	return banner
+}